@@ -0,0 +1,63 @@
+/*
+Copyright 2023 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// CRDSource is a union type selecting the provider used to obtain the
+// control plane CRDs. Exactly one field should be set.
+type CRDSource struct {
+	// LocalDir sources the CRDs from a directory already present on the
+	// host filesystem, skipping download entirely.
+	// +optional
+	LocalDir *LocalDirCRDSource `json:"localDir,omitempty"`
+
+	// HTTPTar sources the CRDs from a tar.gz archive served over HTTP(S).
+	// This is the historical behavior driven by CrdsRemoteURL.
+	// +optional
+	HTTPTar *HTTPTarCRDSource `json:"httpTar,omitempty"`
+
+	// OCI sources the CRDs from an OCI artifact reference.
+	// +optional
+	OCI *OCICRDSource `json:"oci,omitempty"`
+}
+
+// LocalDirCRDSource points at a directory of CRD YAML files already
+// present on the host.
+type LocalDirCRDSource struct {
+	// Path is the absolute path to the directory containing CRD manifests.
+	Path string `json:"path"`
+}
+
+// HTTPTarCRDSource points at a tar.gz archive of CRD manifests served over
+// HTTP(S).
+type HTTPTarCRDSource struct {
+	// URL is the location of the crds.tar.gz archive.
+	URL string `json:"url"`
+}
+
+// OCICRDSource points at an OCI artifact reference carrying CRD manifests,
+// e.g. "registry.example.com/karmada/crds:v1.9.0".
+type OCICRDSource struct {
+	// Reference is the OCI image reference of the CRD artifact.
+	Reference string `json:"reference"`
+
+	// PullSecretRef optionally names a Secret in the operator's namespace
+	// used to authenticate against the registry. Only anonymous pulls are
+	// performed: a non-empty PullSecretRef makes the CRD fetch fail with an
+	// error.
+	// +optional
+	PullSecretRef string `json:"pullSecretRef,omitempty"`
+}
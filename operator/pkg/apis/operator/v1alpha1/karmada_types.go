@@ -0,0 +1,98 @@
+/*
+Copyright 2023 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CRDDownloadPolicy defines the policy used to determine whether the CRD
+// tarball should be (re)downloaded before unpacking.
+type CRDDownloadPolicy string
+
+const (
+	// DownloadAlways means the CRD tarball is downloaded on every reconcile,
+	// regardless of whether it already exists in the local cache.
+	DownloadAlways CRDDownloadPolicy = "Always"
+	// DownloadIfNotPresent means the CRD tarball is only downloaded when it
+	// is missing from the local cache.
+	DownloadIfNotPresent CRDDownloadPolicy = "IfNotPresent"
+)
+
+// KarmadaSpec is the specification of the desired behavior of the Karmada.
+// Only the fields consumed by the CRD preparation tasks are modeled here;
+// the full spec carries many more control-plane component settings.
+type KarmadaSpec struct {
+	// CRDSource selects where the control plane CRDs are fetched from.
+	// Exactly one of its fields should be set; when empty, HTTPTar is
+	// assumed for backward compatibility with CrdsRemoteURL.
+	// +optional
+	CRDSource *CRDSource `json:"crdSource,omitempty"`
+
+	// CRDDownloadPolicy determines whether CRDs are redownloaded even if
+	// present in the local cache.
+	// +optional
+	// +kubebuilder:default=IfNotPresent
+	CRDDownloadPolicy CRDDownloadPolicy `json:"crdDownloadPolicy,omitempty"`
+
+	// CRDTarball carries integrity and authenticity verification settings
+	// applied to a downloaded crds.tar.gz before it is unpacked. It only
+	// takes effect for the HTTP tarball CRD source.
+	// +optional
+	CRDTarball *CRDTarball `json:"crdTarball,omitempty"`
+
+	// CRDDownload configures retry and timeout behavior for fetching CRDs
+	// from a remote source. It only takes effect for the HTTP tarball CRD
+	// source.
+	// +optional
+	CRDDownload *CRDDownload `json:"crdDownload,omitempty"`
+}
+
+// CRDDownload configures the retry loop used to fetch a remote CRD tarball.
+type CRDDownload struct {
+	// MaxAttempts is the maximum number of download attempts before giving
+	// up. Defaults to 5.
+	// +optional
+	// +kubebuilder:default=5
+	MaxAttempts int32 `json:"maxAttempts,omitempty"`
+
+	// PerAttemptTimeout bounds how long a single download attempt may run
+	// before it is cancelled and retried. Defaults to 5m.
+	// +optional
+	// +kubebuilder:default="5m"
+	PerAttemptTimeout metav1.Duration `json:"perAttemptTimeout,omitempty"`
+}
+
+// CRDTarball configures verification of a downloaded CRD tarball.
+type CRDTarball struct {
+	// SHA256 is the expected hex-encoded sha256 checksum of the crds.tar.gz
+	// archive. When set, the download is rejected if the computed checksum
+	// doesn't match.
+	// +optional
+	SHA256 string `json:"sha256,omitempty"`
+
+	// CosignPublicKey is a PEM-encoded public key used to verify a cosign
+	// detached signature of the archive. When set, SignatureURL (or
+	// "<url>.sig" when SignatureURL is empty) must serve a valid signature.
+	// +optional
+	CosignPublicKey string `json:"cosignPublicKey,omitempty"`
+
+	// SignatureURL overrides the location the detached cosign signature is
+	// fetched from. Defaults to the tarball URL with a ".sig" suffix.
+	// +optional
+	SignatureURL string `json:"signatureURL,omitempty"`
+}
@@ -0,0 +1,246 @@
+/*
+Copyright 2023 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package crdcache manages the on-disk cache of downloaded/unpacked CRDs
+// shared by the CRD preparation tasks. A single cache directory may be
+// shared across multiple Karmada instances on the same host, so every
+// mutation of an entry is guarded by a file lock and entry access times are
+// tracked for later pruning.
+package crdcache
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	indexFileName = "index.json"
+	locksDirName  = "locks"
+
+	// indexLockKey is the Lock() key guarding index.json itself. index.json
+	// isn't per-entry, so it must not be protected by whatever per-entry
+	// lock a caller happens to be holding for an unrelated key.
+	indexLockKey = "index"
+)
+
+// Manager tracks last-access times for entries of a shared CRD cache
+// directory and prunes them on request.
+type Manager struct {
+	baseDir string
+
+	// mu serializes index.json reads/writes from this process; Lock/flock
+	// additionally serializes across processes sharing baseDir.
+	mu sync.Mutex
+}
+
+// NewManager returns a Manager rooted at baseDir, creating it if necessary.
+func NewManager(baseDir string) (*Manager, error) {
+	if err := os.MkdirAll(filepath.Join(baseDir, locksDirName), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create cache base directory %s: %w", baseDir, err)
+	}
+	return &Manager{baseDir: baseDir}, nil
+}
+
+// EntryDir returns the directory an entry keyed by key is stored in.
+func (m *Manager) EntryDir(key string) string {
+	return filepath.Join(m.baseDir, key)
+}
+
+// index is the on-disk format of index.json: entry key to last-access time.
+type index map[string]time.Time
+
+func (m *Manager) readIndex() (index, error) {
+	data, err := os.ReadFile(filepath.Join(m.baseDir, indexFileName))
+	if os.IsNotExist(err) {
+		return index{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	idx := index{}
+	if err := json.Unmarshal(data, &idx); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", indexFileName, err)
+	}
+	return idx, nil
+}
+
+func (m *Manager) writeIndex(idx index) error {
+	data, err := json.MarshalIndent(idx, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(m.baseDir, indexFileName), data, 0600)
+}
+
+// withIndexLock runs fn with index.json loaded, under a lock dedicated to
+// index.json itself (indexLockKey), then persists whatever fn returns.
+// Callers must not rely on any per-entry Lock they may be holding to also
+// protect index.json: the two guard different files and must be acquired
+// independently.
+func (m *Manager) withIndexLock(fn func(idx index) (index, error)) error {
+	unlock, err := m.Lock(indexLockKey)
+	if err != nil {
+		return err
+	}
+	defer unlock() //nolint:errcheck
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	idx, err := m.readIndex()
+	if err != nil {
+		return err
+	}
+
+	idx, err = fn(idx)
+	if err != nil {
+		return err
+	}
+	return m.writeIndex(idx)
+}
+
+// Touch records key as accessed just now, used both when an entry is
+// (re)populated and when an existing entry is reused from the cache.
+func (m *Manager) Touch(key string) error {
+	return m.withIndexLock(func(idx index) (index, error) {
+		idx[key] = time.Now()
+		return idx, nil
+	})
+}
+
+// Prune removes cache entries that haven't been accessed in maxAge, then,
+// if the cache still exceeds maxBytes, removes the least-recently-accessed
+// remaining entries until it doesn't. A zero maxAge or maxBytes disables
+// that criterion.
+//
+// Each entry directory is removed while holding that entry's own Lock, the
+// same lock runCrdsDownload/runUnpack hold while writing it, so Prune can
+// never hand a concurrent reconcile a half-deleted directory. Prune never
+// holds the index lock and an entry lock at the same time: it releases the
+// index lock before locking any entry. The only path that nests them is a
+// caller holding an entry lock while calling Touch, which always takes the
+// entry lock first and the index lock second, so the two can't deadlock.
+func (m *Manager) Prune(maxAge time.Duration, maxBytes int64) error {
+	var idx index
+	if err := m.withIndexLock(func(i index) (index, error) {
+		idx = i
+		return i, nil
+	}); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	sizes := make(map[string]int64, len(idx))
+	for key := range idx {
+		sizes[key] = dirSize(m.EntryDir(key))
+	}
+
+	removed := make(map[string]struct{})
+
+	if maxAge > 0 {
+		for key, lastAccess := range idx {
+			if now.Sub(lastAccess) <= maxAge {
+				continue
+			}
+			if err := m.removeEntry(key, lastAccess, "stale cache entry"); err != nil {
+				return err
+			}
+			removed[key] = struct{}{}
+			delete(sizes, key)
+		}
+	}
+
+	if maxBytes > 0 {
+		var total int64
+		for _, size := range sizes {
+			total += size
+		}
+
+		if total > maxBytes {
+			type entry struct {
+				key        string
+				lastAccess time.Time
+			}
+			ordered := make([]entry, 0, len(idx))
+			for key, lastAccess := range idx {
+				if _, ok := removed[key]; ok {
+					continue
+				}
+				ordered = append(ordered, entry{key, lastAccess})
+			}
+			sort.Slice(ordered, func(i, j int) bool { return ordered[i].lastAccess.Before(ordered[j].lastAccess) })
+
+			for _, e := range ordered {
+				if total <= maxBytes {
+					break
+				}
+				if err := m.removeEntry(e.key, e.lastAccess, "cache entry to satisfy max size"); err != nil {
+					return err
+				}
+				removed[e.key] = struct{}{}
+				total -= sizes[e.key]
+			}
+		}
+	}
+
+	if len(removed) == 0 {
+		return nil
+	}
+
+	return m.withIndexLock(func(idx index) (index, error) {
+		for key := range removed {
+			delete(idx, key)
+		}
+		return idx, nil
+	})
+}
+
+// removeEntry deletes the cache entry keyed by key while holding that
+// entry's own lock, so it can't race a concurrent download/unpack of the
+// same entry.
+func (m *Manager) removeEntry(key string, lastAccess time.Time, reason string) error {
+	unlock, err := m.Lock(key)
+	if err != nil {
+		return fmt.Errorf("failed to lock cache entry %s for pruning: %w", key, err)
+	}
+	defer unlock() //nolint:errcheck
+
+	klog.V(2).InfoS("[crdcache] Pruning "+reason, "key", key, "lastAccess", lastAccess)
+	if err := os.RemoveAll(m.EntryDir(key)); err != nil {
+		return fmt.Errorf("failed to remove cache entry %s: %w", key, err)
+	}
+	return nil
+}
+
+func dirSize(dir string) int64 {
+	var total int64
+	_ = filepath.Walk(dir, func(_ string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return nil //nolint:nilerr
+		}
+		total += info.Size()
+		return nil
+	})
+	return total
+}
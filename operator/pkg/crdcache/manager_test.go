@@ -0,0 +1,147 @@
+/*
+Copyright 2023 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crdcache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestManager(t *testing.T) *Manager {
+	t.Helper()
+	m, err := NewManager(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewManager returned error: %v", err)
+	}
+	return m
+}
+
+func writeEntry(t *testing.T, m *Manager, key string, size int) {
+	t.Helper()
+	dir := m.EntryDir(key)
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		t.Fatalf("failed to create entry dir %s: %v", key, err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "data"), make([]byte, size), 0640); err != nil {
+		t.Fatalf("failed to write entry data for %s: %v", key, err)
+	}
+}
+
+func TestManagerTouch(t *testing.T) {
+	m := newTestManager(t)
+	writeEntry(t, m, "key1", 10)
+
+	before := time.Now()
+	if err := m.Touch("key1"); err != nil {
+		t.Fatalf("Touch returned error: %v", err)
+	}
+
+	idx, err := m.readIndex()
+	if err != nil {
+		t.Fatalf("readIndex returned error: %v", err)
+	}
+	lastAccess, ok := idx["key1"]
+	if !ok {
+		t.Fatalf("expected key1 to be recorded in the index")
+	}
+	if lastAccess.Before(before) {
+		t.Errorf("lastAccess %v recorded before Touch was called at %v", lastAccess, before)
+	}
+}
+
+func TestManagerPruneMaxAge(t *testing.T) {
+	m := newTestManager(t)
+
+	writeEntry(t, m, "stale", 10)
+	writeEntry(t, m, "fresh", 10)
+	if err := m.writeIndex(index{
+		"stale": time.Now().Add(-time.Hour),
+		"fresh": time.Now(),
+	}); err != nil {
+		t.Fatalf("writeIndex returned error: %v", err)
+	}
+
+	if err := m.Prune(time.Minute, 0); err != nil {
+		t.Fatalf("Prune returned error: %v", err)
+	}
+
+	if exist, _ := pathExists(m.EntryDir("stale")); exist {
+		t.Errorf("expected stale entry to be removed")
+	}
+	if exist, _ := pathExists(m.EntryDir("fresh")); !exist {
+		t.Errorf("expected fresh entry to be kept")
+	}
+
+	idx, err := m.readIndex()
+	if err != nil {
+		t.Fatalf("readIndex returned error: %v", err)
+	}
+	if _, ok := idx["stale"]; ok {
+		t.Errorf("expected stale entry to be removed from the index")
+	}
+	if _, ok := idx["fresh"]; !ok {
+		t.Errorf("expected fresh entry to remain in the index")
+	}
+}
+
+func TestManagerPruneMaxBytes(t *testing.T) {
+	m := newTestManager(t)
+
+	writeEntry(t, m, "oldest", 100)
+	writeEntry(t, m, "newest", 100)
+	now := time.Now()
+	if err := m.writeIndex(index{
+		"oldest": now.Add(-time.Hour),
+		"newest": now,
+	}); err != nil {
+		t.Fatalf("writeIndex returned error: %v", err)
+	}
+
+	// Total size is 200 bytes; capping at 150 must evict only the
+	// least-recently-accessed entry.
+	if err := m.Prune(0, 150); err != nil {
+		t.Fatalf("Prune returned error: %v", err)
+	}
+
+	if exist, _ := pathExists(m.EntryDir("oldest")); exist {
+		t.Errorf("expected oldest entry to be evicted to satisfy maxBytes")
+	}
+	if exist, _ := pathExists(m.EntryDir("newest")); !exist {
+		t.Errorf("expected newest entry to be kept")
+	}
+
+	idx, err := m.readIndex()
+	if err != nil {
+		t.Fatalf("readIndex returned error: %v", err)
+	}
+	if _, ok := idx["oldest"]; ok {
+		t.Errorf("expected oldest entry to be removed from the index")
+	}
+}
+
+func pathExists(path string) (bool, error) {
+	_, err := os.Stat(path)
+	if err == nil {
+		return true, nil
+	}
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return false, err
+}
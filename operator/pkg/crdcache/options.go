@@ -0,0 +1,76 @@
+/*
+Copyright 2023 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crdcache
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// Options holds the operator CLI flags controlling the shared CRD cache.
+type Options struct {
+	// Dir is the shared cache directory. Defaults to "<DataDir>/cache" when
+	// empty.
+	Dir string
+	// MaxAge is the maximum time an entry may go unaccessed before Prune
+	// removes it. Zero disables age-based pruning.
+	MaxAge time.Duration
+	// MaxSize is the maximum total size, in bytes, the cache may grow to
+	// before Prune evicts the least-recently-accessed entries. Zero disables
+	// size-based pruning.
+	MaxSize int64
+	// PruneInterval is how often the background pruner calls Prune. Zero
+	// disables the background pruner; Prune can still be invoked directly.
+	PruneInterval time.Duration
+}
+
+// AddFlags binds the CRD cache options to fs.
+func (o *Options) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.Dir, "crd-cache-dir", o.Dir,
+		"Directory used to cache downloaded/unpacked CRDs. Defaults to <data-dir>/cache and may be shared across Karmada instances on the same host.")
+	fs.DurationVar(&o.MaxAge, "crd-cache-max-age", 30*24*time.Hour,
+		"Maximum time a CRD cache entry may go unaccessed before it is pruned. Zero disables age-based pruning.")
+	fs.Int64Var(&o.MaxSize, "crd-cache-max-size", 0,
+		"Maximum total size, in bytes, of the CRD cache directory before least-recently-accessed entries are pruned. Zero disables size-based pruning.")
+	fs.DurationVar(&o.PruneInterval, "crd-cache-prune-interval", time.Hour,
+		"How often the CRD cache is checked for entries to prune. Zero disables the background pruner.")
+}
+
+// Setup resolves dir (falling back to "<dataDir>/cache" when o.Dir is
+// empty), constructs the Manager rooted there, and starts its background
+// pruner goroutine governed by ctx. This is the single call operator
+// startup is expected to make once AddFlags has parsed the CLI flags into
+// o: constructing a Manager without also starting the pruner would leave
+// the cache growing unbounded despite the flags suggesting otherwise.
+func (o Options) Setup(ctx context.Context, dataDir string) (*Manager, error) {
+	dir := o.Dir
+	if dir == "" {
+		dir = filepath.Join(dataDir, "cache")
+	}
+
+	m, err := NewManager(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	go m.RunPruner(ctx, o.PruneInterval, o.MaxAge, o.MaxSize)
+
+	return m, nil
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2023 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crdcache
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// RunPruner periodically calls Prune until ctx is cancelled. It is meant to
+// be started once from operator startup alongside the other background
+// controllers.
+func (m *Manager) RunPruner(ctx context.Context, interval, maxAge time.Duration, maxBytes int64) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := m.Prune(maxAge, maxBytes); err != nil {
+				klog.ErrorS(err, "[crdcache] Failed to prune CRD cache")
+			}
+		}
+	}
+}
@@ -17,15 +17,13 @@ limitations under the License.
 package tasks
 
 import (
-	"crypto/sha256"
-	"encoding/hex"
 	"errors"
 	"fmt"
-	operatorv1alpha1 "github.com/karmada-io/karmada/operator/pkg/apis/operator/v1alpha1"
 	"os"
 	"path"
 	"strings"
 
+	operatorv1alpha1 "github.com/karmada-io/karmada/operator/pkg/apis/operator/v1alpha1"
 	"k8s.io/klog/v2"
 
 	"github.com/karmada-io/karmada/operator/pkg/util"
@@ -62,6 +60,9 @@ func runPrepareCrds(r workflow.RunData) error {
 	if !ok {
 		return errors.New("prepare-crds task invoked with an invalid data struct")
 	}
+	if err := data.Context().Err(); err != nil {
+		return fmt.Errorf("prepare-crds task aborted, err: %w", err)
+	}
 
 	crdsDir := getCrdsDir(data)
 	klog.V(4).InfoS("[prepare-crds] Running prepare-crds task", "karmada", klog.KObj(data))
@@ -76,11 +77,20 @@ func skipCrdsDownload(r workflow.RunData) (bool, error) {
 		return false, errors.New("prepare-crds task invoked with an invalid data struct")
 	}
 
+	if err := data.Context().Err(); err != nil {
+		return false, fmt.Errorf("skipCrdsDownload aborted, err: %w", err)
+	}
+
 	if data.CrdDownloadPolicy() == operatorv1alpha1.DownloadAlways {
 		klog.V(2).InfoS("[skipCrdsDownload] CrdDownloadPolicy is 'Always', skipping download check")
 		return false, nil
 	}
 
+	source, err := data.CRDSource()
+	if err != nil {
+		return false, fmt.Errorf("failed to resolve CRD source, err: %w", err)
+	}
+
 	crdsDir := getCrdsDir(data)
 	klog.V(2).InfoS("[skipCrdsDownload] Checking if CRDs need to be downloaded", "folder", crdsDir)
 
@@ -89,12 +99,24 @@ func skipCrdsDownload(r workflow.RunData) (bool, error) {
 		return false, err
 	}
 
-	if !existCrdsTar(crdsDir) {
-		klog.V(2).InfoS("[skipCrdsDownload] CRD tar file does not exist", "folder", crdsDir)
+	// Delegate the "is there already a usable fetch in the cache entry"
+	// check to the resolved provider: what counts as present differs
+	// between a tarball (httpTar, oci) and loose files (localDir), and this
+	// must stay in sync with whatever Fetch actually writes.
+	present, err := source.AlreadyFetched(crdsDir)
+	if err != nil {
+		return false, fmt.Errorf("failed to check whether CRDs are already fetched, err: %w", err)
+	}
+	if !present {
+		klog.V(2).InfoS("[skipCrdsDownload] CRDs are not present in the cache entry", "folder", crdsDir)
 		return false, nil
 	}
 
-	klog.V(2).InfoS("[download-crds] Skip download CRD yaml files, the CRD tar exists on disk", "karmada", klog.KObj(data), "folder", crdsDir)
+	if err := data.CRDCache().Touch(crdCacheKey(data)); err != nil {
+		klog.ErrorS(err, "[skipCrdsDownload] Failed to record cache access", "folder", crdsDir)
+	}
+
+	klog.V(2).InfoS("[download-crds] Skip download CRDs, they are already present in the cache entry", "karmada", klog.KObj(data), "folder", crdsDir)
 	return true, nil
 }
 
@@ -104,9 +126,27 @@ func runCrdsDownload(r workflow.RunData) error {
 		return errors.New("download-crds task invoked with an invalid data struct")
 	}
 
-	crdsDir := getCrdsDir(data)
-	crdsTarPath := path.Join(crdsDir, crdsFileSuffix)
-	klog.V(2).InfoS("[runCrdsDownload] Starting CRDs download", "folder", crdsDir, "remoteURL", data.CrdsRemoteURL())
+	source, err := data.CRDSource()
+	if err != nil {
+		return fmt.Errorf("failed to resolve CRD source, err: %w", err)
+	}
+
+	cacheKey := crdCacheKey(data)
+	crdsDir := data.CRDCache().EntryDir(cacheKey)
+	klog.V(2).InfoS("[runCrdsDownload] Starting CRDs fetch", "folder", crdsDir)
+
+	// Hold the cache entry's lock for the rest of the download so that two
+	// Karmada instances sharing this cache directory don't race each other
+	// fetching the same entry.
+	unlock, err := data.CRDCache().Lock(cacheKey)
+	if err != nil {
+		return fmt.Errorf("failed to lock CRD cache entry, err: %w", err)
+	}
+	defer func() {
+		if err := unlock(); err != nil {
+			klog.ErrorS(err, "[runCrdsDownload] Failed to release cache entry lock", "folder", crdsDir)
+		}
+	}()
 
 	// Check if the CRDs directory exists
 	exist, err := util.PathExists(crdsDir)
@@ -128,13 +168,22 @@ func runCrdsDownload(r workflow.RunData) error {
 		return fmt.Errorf("failed to create CRDs directory, err: %w", err)
 	}
 
-	// Download the CRD tar file
-	klog.V(2).InfoS("[runCrdsDownload] Downloading CRD tar file", "remoteURL", data.CrdsRemoteURL(), "tarPath", crdsTarPath)
-	if err := util.DownloadFile(data.CrdsRemoteURL(), crdsTarPath); err != nil {
-		return fmt.Errorf("failed to download CRD tar, err: %w", err)
+	// Fetch the CRDs using the resolved source provider and the reconcile's
+	// context, so an aborted reconcile cancels an in-flight download instead
+	// of running unbounded. For the HTTP tarball source this also retries
+	// with backoff and verifies the archive's checksum and cosign signature,
+	// if configured, before returning successfully, so runUnpack never sees
+	// an untrusted or partial file.
+	klog.V(2).InfoS("[runCrdsDownload] Fetching CRDs", "folder", crdsDir)
+	if err := source.Fetch(data.Context(), crdsDir); err != nil {
+		return fmt.Errorf("failed to fetch CRDs, err: %w", err)
+	}
+
+	if err := data.CRDCache().Touch(cacheKey); err != nil {
+		klog.ErrorS(err, "[runCrdsDownload] Failed to record cache access", "folder", crdsDir)
 	}
 
-	klog.V(2).InfoS("[runCrdsDownload] Successfully downloaded CRD package from remote URL", "remoteURL", data.CrdsRemoteURL(), "folder", crdsDir)
+	klog.V(2).InfoS("[runCrdsDownload] Successfully fetched CRD package", "folder", crdsDir)
 	return nil
 }
 
@@ -143,12 +192,26 @@ func runUnpack(r workflow.RunData) error {
 	if !ok {
 		return errors.New("unpack task invoked with an invalid data struct")
 	}
+	if err := data.Context().Err(); err != nil {
+		return fmt.Errorf("unpack task aborted, err: %w", err)
+	}
 
-	crdsDir := getCrdsDir(data)
+	cacheKey := crdCacheKey(data)
+	crdsDir := data.CRDCache().EntryDir(cacheKey)
 	crdsTarPath := path.Join(crdsDir, crdsFileSuffix)
 	crdsPath := path.Join(crdsDir, crdPathSuffix)
 	klog.V(2).InfoS("[runUnpack] Starting to unpack CRDs", "tarPath", crdsTarPath, "unpackDir", crdsDir)
 
+	unlock, err := data.CRDCache().Lock(cacheKey)
+	if err != nil {
+		return fmt.Errorf("failed to lock CRD cache entry, err: %w", err)
+	}
+	defer func() {
+		if err := unlock(); err != nil {
+			klog.ErrorS(err, "[runUnpack] Failed to release cache entry lock", "unpackDir", crdsDir)
+		}
+	}()
+
 	exist, _ := util.PathExists(crdsPath)
 	if !exist {
 		klog.V(2).InfoS("[runUnpack] CRD yaml files do not exist, unpacking tar file", "unpackDir", crdsDir)
@@ -159,6 +222,14 @@ func runUnpack(r workflow.RunData) error {
 		klog.V(2).InfoS("[unpack] These CRDs yaml files have been decompressed in the path", "path", crdsPath, "karmada", klog.KObj(data))
 	}
 
+	// Normalize the resulting tree, whether it came from the tar or was
+	// placed directly by a loose-file CRD source, into a flat, de-duplicated
+	// set of CRDs so downstream tasks see a consistent crdsPath regardless
+	// of source layout.
+	if err := normalizeCRDDir(crdsPath); err != nil {
+		return fmt.Errorf("[unpack] failed to normalize CRD directory, err: %w", err)
+	}
+
 	klog.V(2).InfoS("[unpack] Successfully unpacked CRD tar", "karmada", klog.KObj(data), "unpackDir", crdsDir)
 	return nil
 }
@@ -169,7 +240,10 @@ func existCrdsTar(crdsDir string) bool {
 
 	for _, file := range files {
 		klog.V(2).InfoS("[existCrdsTar] Checking file", "fileName", file.Name(), "fileSize", file.Size())
-		if strings.Contains(file.Name(), crdsFileSuffix) && file.Size() > 0 {
+		// Match the tarball name exactly: a "crds.tar.gz.part" left behind by
+		// an interrupted DownloadResumable attempt also contains
+		// crdsFileSuffix as a substring and must not be mistaken for it.
+		if file.Name() == crdsFileSuffix && file.Size() > 0 {
 			klog.V(2).InfoS("[existCrdsTar] Found CRD tar file", "fileName", file.Name(), "fileSize", file.Size())
 			return true
 		}
@@ -178,8 +252,19 @@ func existCrdsTar(crdsDir string) bool {
 }
 
 func getCrdsDir(data InitData) string {
-	url := strings.TrimSpace(data.CrdsRemoteURL())
-	hash := sha256.Sum256([]byte(url))
-	hashStr := hex.EncodeToString(hash[:])
-	return path.Join(data.DataDir(), "cache", hashStr)
+	return data.CRDCache().EntryDir(crdCacheKey(data))
+}
+
+// crdCacheKey returns the stable key the CRD cache manager stores this
+// Karmada instance's resolved CRD source under.
+func crdCacheKey(data InitData) string {
+	source, err := data.CRDSource()
+	if err != nil {
+		// Fall back to the legacy remote URL so a misconfigured CRDSource
+		// doesn't block callers that only need a cache key, e.g.
+		// runPrepareCrds; skipCrdsDownload and runCrdsDownload resolve the
+		// source themselves and surface the real error.
+		return digestString(strings.TrimSpace(data.CrdsRemoteURL()))
+	}
+	return source.Digest()
 }
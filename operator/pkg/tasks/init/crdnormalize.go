@@ -0,0 +1,155 @@
+/*
+Copyright 2023 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/klog/v2"
+)
+
+const crdKind = "CustomResourceDefinition"
+
+// crdDocument is a single CustomResourceDefinition document discovered while
+// walking a CRD directory.
+type crdDocument struct {
+	key     string // group/kind/name
+	path    string
+	content []byte
+}
+
+// normalizeCRDDir recursively walks dir for *.yaml/*.yml files, splits
+// multi-document files, keeps only CustomResourceDefinition documents and
+// de-duplicates them by group+kind+name, last write wins with a warning
+// logged for the discarded document. dir is then rewritten with exactly one
+// file per CRD, so downstream tasks that consume crdsPath see a normalized
+// set regardless of whether it came from a tarball, a local directory, or a
+// chart-style crds/ subdirectory. This mirrors the walking/dedup behavior
+// controller-runtime's envtest applies to renderCRDs across multiple Paths.
+func normalizeCRDDir(dir string) error {
+	byKey := make(map[string]crdDocument)
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		ext := strings.ToLower(filepath.Ext(path))
+		if ext != ".yaml" && ext != ".yml" {
+			return nil
+		}
+
+		docs, err := splitCRDDocuments(path)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+
+		for _, doc := range docs {
+			if existing, ok := byKey[doc.key]; ok {
+				klog.Warningf("[normalizeCRDDir] duplicate CRD %s found, keeping the later one: discarded %s, kept %s", doc.key, existing.path, doc.path)
+			}
+			byKey[doc.key] = doc
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("failed to clear %s before writing normalized CRDs: %w", dir, err)
+	}
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return fmt.Errorf("failed to recreate %s: %w", dir, err)
+	}
+
+	for key, doc := range byKey {
+		name := strings.ReplaceAll(key, "/", "_") + ".yaml"
+		if err := os.WriteFile(filepath.Join(dir, name), doc.content, 0640); err != nil {
+			return fmt.Errorf("failed to write normalized CRD %s: %w", key, err)
+		}
+	}
+
+	klog.V(2).InfoS("[normalizeCRDDir] Normalized CRD set", "folder", dir, "count", len(byKey))
+	return nil
+}
+
+// splitCRDDocuments splits a multi-document YAML file and returns the
+// CustomResourceDefinition documents it contains.
+func splitCRDDocuments(path string) ([]crdDocument, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	reader := k8syaml.NewYAMLReader(bufio.NewReader(f))
+
+	var docs []crdDocument
+	for {
+		raw, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(bytes.TrimSpace(raw)) == 0 {
+			continue
+		}
+
+		jsonBytes, err := k8syaml.ToJSON(raw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert document to JSON: %w", err)
+		}
+
+		var obj struct {
+			Kind     string `json:"kind"`
+			Metadata struct {
+				Name string `json:"name"`
+			} `json:"metadata"`
+			Spec struct {
+				Group string `json:"group"`
+				Names struct {
+					Kind string `json:"kind"`
+				} `json:"names"`
+			} `json:"spec"`
+		}
+		if err := json.Unmarshal(jsonBytes, &obj); err != nil {
+			return nil, fmt.Errorf("failed to decode document: %w", err)
+		}
+
+		if obj.Kind != crdKind {
+			continue
+		}
+
+		key := fmt.Sprintf("%s/%s/%s", obj.Spec.Group, obj.Spec.Names.Kind, obj.Metadata.Name)
+		docs = append(docs, crdDocument{key: key, path: path, content: raw})
+	}
+	return docs, nil
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2023 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+const fooCRDYAML = `
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: foos.example.com
+spec:
+  group: example.com
+  names:
+    kind: Foo
+`
+
+const barCRDYAML = `
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: bars.example.com
+spec:
+  group: example.com
+  names:
+    kind: Bar
+`
+
+func TestSplitCRDDocuments(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "multi.yaml")
+
+	content := fooCRDYAML + "---\n" + barCRDYAML + "---\n" + `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: not-a-crd
+`
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	docs, err := splitCRDDocuments(path)
+	if err != nil {
+		t.Fatalf("splitCRDDocuments returned error: %v", err)
+	}
+
+	if len(docs) != 2 {
+		t.Fatalf("expected 2 CRD documents, got %d", len(docs))
+	}
+
+	keys := []string{docs[0].key, docs[1].key}
+	sort.Strings(keys)
+	want := []string{"example.com/Bar/bars.example.com", "example.com/Foo/foos.example.com"}
+	for i := range want {
+		if keys[i] != want[i] {
+			t.Errorf("keys[%d] = %q, want %q", i, keys[i], want[i])
+		}
+	}
+}
+
+func TestNormalizeCRDDir(t *testing.T) {
+	dir := t.TempDir()
+
+	// A chart-style nested layout with a duplicate of "foo" across two
+	// files, to exercise both the recursive walk and the last-write-wins
+	// de-duplication.
+	if err := os.MkdirAll(filepath.Join(dir, "nested"), 0750); err != nil {
+		t.Fatalf("failed to create nested dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "a.yaml"), []byte(fooCRDYAML), 0600); err != nil {
+		t.Fatalf("failed to write a.yaml: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "nested", "b.yml"), []byte(fooCRDYAML+"---\n"+barCRDYAML), 0600); err != nil {
+		t.Fatalf("failed to write nested/b.yml: %v", err)
+	}
+
+	if err := normalizeCRDDir(dir); err != nil {
+		t.Fatalf("normalizeCRDDir returned error: %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("failed to read normalized dir: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 normalized files, got %d", len(entries))
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	sort.Strings(names)
+	want := []string{"example.com_Bar_bars.example.com.yaml", "example.com_Foo_foos.example.com.yaml"}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Errorf("names[%d] = %q, want %q", i, names[i], want[i])
+		}
+	}
+}
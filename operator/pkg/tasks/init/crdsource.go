@@ -0,0 +1,245 @@
+/*
+Copyright 2023 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	operatorv1alpha1 "github.com/karmada-io/karmada/operator/pkg/apis/operator/v1alpha1"
+	"github.com/karmada-io/karmada/operator/pkg/util"
+)
+
+// CRDSource fetches the control plane CRDs from some backing location into
+// a local destination directory. Implementations are selected based on the
+// Karmada CR's spec.crdSource and hide the details of where CRDs actually
+// come from (local disk, an HTTP tarball, an OCI artifact, ...) from the
+// tasks that consume them.
+type CRDSource interface {
+	// Fetch populates destDir with the CRD artifacts. Implementations that
+	// produce a tarball write it as crds.tar.gz inside destDir for a later
+	// unpack step; implementations that produce loose YAML files write them
+	// directly into destDir's "crds" subdirectory, the same location the
+	// tarball is unpacked to.
+	Fetch(ctx context.Context, destDir string) error
+
+	// Digest returns a stable identifier for the source's current content,
+	// used to key the on-disk cache. It does not need to be cryptographic;
+	// it only needs to change when the underlying content changes.
+	Digest() string
+
+	// AlreadyFetched reports whether destDir already holds a complete,
+	// previously-fetched copy of this source's CRDs, so skipCrdsDownload
+	// can honor CRDDownloadPolicy: IfNotPresent for whichever provider
+	// populated the cache entry, not just the HTTP tarball.
+	AlreadyFetched(destDir string) (bool, error)
+}
+
+// NewCRDSource resolves the CRDSource implementation selected by spec, falling
+// back to the legacy HTTP tarball behavior driven by legacyRemoteURL when spec
+// is nil. tarball carries optional integrity/authenticity verification
+// settings, and download carries retry/timeout settings, both applied by the
+// HTTP tarball source.
+func NewCRDSource(spec *operatorv1alpha1.CRDSource, legacyRemoteURL string, tarball *operatorv1alpha1.CRDTarball, download *operatorv1alpha1.CRDDownload) (CRDSource, error) {
+	if spec == nil {
+		return &httpTarCRDSource{url: legacyRemoteURL, verify: tarball, download: download}, nil
+	}
+
+	set := 0
+	var source CRDSource
+	if spec.LocalDir != nil {
+		set++
+		source = &localDirCRDSource{path: spec.LocalDir.Path}
+	}
+	if spec.HTTPTar != nil {
+		set++
+		source = &httpTarCRDSource{url: spec.HTTPTar.URL, verify: tarball, download: download}
+	}
+	if spec.OCI != nil {
+		set++
+		source = &ociCRDSource{reference: spec.OCI.Reference, pullSecretRef: spec.OCI.PullSecretRef}
+	}
+
+	switch set {
+	case 0:
+		return nil, fmt.Errorf("crdSource must set exactly one of localDir, httpTar or oci")
+	case 1:
+		return source, nil
+	default:
+		return nil, fmt.Errorf("crdSource must set exactly one of localDir, httpTar or oci, got %d", set)
+	}
+}
+
+// localDirCRDSource mirrors CRD YAML files already present on the host into
+// the cache directory, skipping any download. It accepts either a directory
+// of loose CRD manifests or a chart-style layout with a nested crds/
+// subdirectory.
+type localDirCRDSource struct {
+	path string
+}
+
+func (s *localDirCRDSource) Fetch(_ context.Context, destDir string) error {
+	srcDir := s.path
+	if nested := filepath.Join(s.path, crdPathSuffix); isDir(nested) {
+		srcDir = nested
+	}
+	if !isDir(srcDir) {
+		return fmt.Errorf("local CRD directory %s does not exist", srcDir)
+	}
+
+	crdsPath := filepath.Join(destDir, crdPathSuffix)
+	if err := util.CopyDir(srcDir, crdsPath); err != nil {
+		return fmt.Errorf("failed to mirror %s into %s: %w", srcDir, crdsPath, err)
+	}
+	return nil
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+func (s *localDirCRDSource) Digest() string {
+	return digestString("localDir:" + s.path)
+}
+
+func (s *localDirCRDSource) AlreadyFetched(destDir string) (bool, error) {
+	return dirHasFiles(filepath.Join(destDir, crdPathSuffix))
+}
+
+// httpTarCRDSource downloads a crds.tar.gz archive over HTTP(S). This is
+// the original, and still default, CRD source.
+type httpTarCRDSource struct {
+	url      string
+	verify   *operatorv1alpha1.CRDTarball
+	download *operatorv1alpha1.CRDDownload
+}
+
+func (s *httpTarCRDSource) Fetch(ctx context.Context, destDir string) error {
+	tarPath := filepath.Join(destDir, crdsFileSuffix)
+
+	digest, err := util.DownloadResumable(ctx, s.url, tarPath, s.downloadOptions())
+	if err != nil {
+		return err
+	}
+
+	if err := s.verifyTarball(ctx, tarPath, digest); err != nil {
+		// Remove the partial/untrusted file so the next reconcile
+		// re-downloads rather than unpacking stale content.
+		_ = os.Remove(tarPath)
+		return err
+	}
+
+	return nil
+}
+
+func (s *httpTarCRDSource) downloadOptions() util.DownloadOptions {
+	opts := util.DefaultDownloadOptions()
+	if s.download == nil {
+		return opts
+	}
+	if s.download.MaxAttempts > 0 {
+		opts.MaxAttempts = int(s.download.MaxAttempts)
+	}
+	if s.download.PerAttemptTimeout.Duration > 0 {
+		opts.PerAttemptTimeout = s.download.PerAttemptTimeout.Duration
+	}
+	return opts
+}
+
+func (s *httpTarCRDSource) verifyTarball(ctx context.Context, tarPath, digest string) error {
+	if s.verify == nil {
+		return nil
+	}
+
+	if s.verify.SHA256 != "" && !strings.EqualFold(s.verify.SHA256, digest) {
+		return fmt.Errorf("sha256 mismatch for %s: expected %s, got %s", s.url, s.verify.SHA256, digest)
+	}
+
+	if s.verify.CosignPublicKey != "" {
+		sigURL := s.verify.SignatureURL
+		if sigURL == "" {
+			sigURL = s.url + ".sig"
+		}
+		if err := util.VerifyCosignSignature(ctx, tarPath, sigURL, s.verify.CosignPublicKey); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *httpTarCRDSource) Digest() string {
+	return digestString("httpTar:" + strings.TrimSpace(s.url))
+}
+
+func (s *httpTarCRDSource) AlreadyFetched(destDir string) (bool, error) {
+	return existCrdsTar(destDir), nil
+}
+
+// ociCRDSource fetches CRDs published as an OCI artifact. The artifact's
+// first layer is expected to be a crds.tar.gz, the same format the HTTP
+// tarball source consumes, so it can be unpacked and normalized the same
+// way regardless of how it was fetched.
+type ociCRDSource struct {
+	reference     string
+	pullSecretRef string
+}
+
+func (s *ociCRDSource) Fetch(ctx context.Context, destDir string) error {
+	tarPath := filepath.Join(destDir, crdsFileSuffix)
+	return util.PullOCIArtifactLayer(ctx, s.reference, s.pullSecretRef, tarPath)
+}
+
+func (s *ociCRDSource) Digest() string {
+	return digestString("oci:" + s.reference)
+}
+
+func (s *ociCRDSource) AlreadyFetched(destDir string) (bool, error) {
+	return existCrdsTar(destDir), nil
+}
+
+func digestString(s string) string {
+	hash := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(hash[:])
+}
+
+// dirHasFiles reports whether path exists and contains at least one regular
+// file, recursively.
+func dirHasFiles(path string) (bool, error) {
+	exist, err := util.PathExists(path)
+	if err != nil || !exist {
+		return false, err
+	}
+
+	found := false
+	err = filepath.Walk(path, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			found = true
+		}
+		return nil
+	})
+	return found, err
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2023 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+
+	operatorv1alpha1 "github.com/karmada-io/karmada/operator/pkg/apis/operator/v1alpha1"
+)
+
+func TestNewCRDSource(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    *operatorv1alpha1.CRDSource
+		wantErr bool
+	}{
+		{
+			name: "nil spec falls back to legacy httpTar",
+			spec: nil,
+		},
+		{
+			name: "localDir set",
+			spec: &operatorv1alpha1.CRDSource{LocalDir: &operatorv1alpha1.LocalDirCRDSource{Path: "/tmp/crds"}},
+		},
+		{
+			name: "httpTar set",
+			spec: &operatorv1alpha1.CRDSource{HTTPTar: &operatorv1alpha1.HTTPTarCRDSource{URL: "https://example.com/crds.tar.gz"}},
+		},
+		{
+			name: "oci set",
+			spec: &operatorv1alpha1.CRDSource{OCI: &operatorv1alpha1.OCICRDSource{Reference: "example.com/crds:v1"}},
+		},
+		{
+			name:    "none set",
+			spec:    &operatorv1alpha1.CRDSource{},
+			wantErr: true,
+		},
+		{
+			name: "two set",
+			spec: &operatorv1alpha1.CRDSource{
+				LocalDir: &operatorv1alpha1.LocalDirCRDSource{Path: "/tmp/crds"},
+				HTTPTar:  &operatorv1alpha1.HTTPTarCRDSource{URL: "https://example.com/crds.tar.gz"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source, err := NewCRDSource(tt.spec, "https://example.com/crds.tar.gz", nil, nil)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if source == nil {
+				t.Fatalf("expected a non-nil CRDSource")
+			}
+		})
+	}
+}
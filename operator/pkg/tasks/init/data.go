@@ -0,0 +1,64 @@
+/*
+Copyright 2023 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"context"
+
+	operatorv1alpha1 "github.com/karmada-io/karmada/operator/pkg/apis/operator/v1alpha1"
+	"github.com/karmada-io/karmada/operator/pkg/crdcache"
+	"k8s.io/klog/v2"
+)
+
+// InitData is the runtime data made available to every task of the init
+// workflow. It is implemented by the init job that drives the workflow and
+// is threaded through workflow.RunData.
+type InitData interface {
+	klog.KMetadata
+
+	// Context returns the context governing the current reconcile. Tasks
+	// must honor its cancellation instead of running unbounded operations.
+	Context() context.Context
+
+	// DataDir returns the root directory the operator uses to persist
+	// downloaded artifacts for this Karmada instance.
+	DataDir() string
+
+	// CrdsRemoteURL returns the configured HTTP(S) location of the
+	// crds.tar.gz archive. Deprecated in favor of CRDSource.
+	CrdsRemoteURL() string
+
+	// CrdDownloadPolicy reports whether CRDs should be redownloaded even
+	// when already present in the local cache.
+	CrdDownloadPolicy() operatorv1alpha1.CRDDownloadPolicy
+
+	// CRDSource resolves the provider used to fetch the control plane
+	// CRDs, derived from the Karmada CR's spec.crdSource.
+	CRDSource() (CRDSource, error)
+
+	// CRDTarballVerification returns the integrity/authenticity checks to
+	// apply to a downloaded crds.tar.gz, or nil when none are configured.
+	CRDTarballVerification() *operatorv1alpha1.CRDTarball
+
+	// CRDDownloadRetry returns the retry/timeout configuration for fetching
+	// a remote CRD tarball, or nil to use the built-in defaults.
+	CRDDownloadRetry() *operatorv1alpha1.CRDDownload
+
+	// CRDCache returns the cache manager tracking the shared, on-disk CRD
+	// cache directory, used to serialize and age out entries.
+	CRDCache() *crdcache.Manager
+}
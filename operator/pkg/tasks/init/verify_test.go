@@ -0,0 +1,56 @@
+/*
+Copyright 2023 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"context"
+	"testing"
+
+	operatorv1alpha1 "github.com/karmada-io/karmada/operator/pkg/apis/operator/v1alpha1"
+)
+
+func TestVerifyTarballNoVerificationConfigured(t *testing.T) {
+	s := &httpTarCRDSource{url: "https://example.com/crds.tar.gz"}
+
+	if err := s.verifyTarball(context.Background(), "/irrelevant/path", "anydigest"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestVerifyTarballSHA256Mismatch(t *testing.T) {
+	s := &httpTarCRDSource{
+		url:    "https://example.com/crds.tar.gz",
+		verify: &operatorv1alpha1.CRDTarball{SHA256: "expecteddigest"},
+	}
+
+	if err := s.verifyTarball(context.Background(), "/irrelevant/path", "actualdigest"); err == nil {
+		t.Fatalf("expected a sha256 mismatch error, got none")
+	}
+}
+
+func TestVerifyTarballSHA256Match(t *testing.T) {
+	s := &httpTarCRDSource{
+		url:    "https://example.com/crds.tar.gz",
+		verify: &operatorv1alpha1.CRDTarball{SHA256: "SAMEDIGEST"},
+	}
+
+	// The comparison is case-insensitive, and with no CosignPublicKey set a
+	// matching digest must pass without attempting a cosign call.
+	if err := s.verifyTarball(context.Background(), "/irrelevant/path", "samedigest"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2023 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// DownloadOptions configures the retry, timeout and progress-reporting
+// behavior of DownloadResumable.
+type DownloadOptions struct {
+	// MaxAttempts is the maximum number of download attempts before giving up.
+	MaxAttempts int
+	// PerAttemptTimeout bounds how long a single attempt may run.
+	PerAttemptTimeout time.Duration
+	// ProgressInterval controls how often a progress line is logged; zero
+	// disables progress logging.
+	ProgressInterval time.Duration
+}
+
+// DefaultDownloadOptions returns sane defaults used when the caller hasn't
+// configured retry behavior.
+func DefaultDownloadOptions() DownloadOptions {
+	return DownloadOptions{
+		MaxAttempts:       5,
+		PerAttemptTimeout: 5 * time.Minute,
+		ProgressInterval:  10 * time.Second,
+	}
+}
+
+// DownloadResumable downloads url into dst with exponential-backoff retries.
+// Interrupted transfers resume via HTTP Range requests into a "<dst>.part"
+// file, which is renamed to dst only once the transfer completes and its
+// sha256 digest (returned hex-encoded) has been computed. ctx governs the
+// whole operation; each attempt is additionally bounded by
+// opts.PerAttemptTimeout.
+func DownloadResumable(ctx context.Context, url, dst string, opts DownloadOptions) (string, error) {
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = 1
+	}
+
+	partPath := dst + ".part"
+	backoff := time.Second
+
+	var lastErr error
+	for attempt := 1; attempt <= opts.MaxAttempts; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+
+		attemptCtx, cancel := context.WithTimeout(ctx, opts.PerAttemptTimeout)
+		digest, err := downloadAttempt(attemptCtx, url, partPath, opts.ProgressInterval)
+		cancel()
+		if err == nil {
+			if err := os.Rename(partPath, dst); err != nil {
+				return "", fmt.Errorf("failed to finalize download of %s: %w", dst, err)
+			}
+			return digest, nil
+		}
+
+		lastErr = err
+		klog.V(2).InfoS("[DownloadResumable] attempt failed, will retry", "url", url, "attempt", attempt, "maxAttempts", opts.MaxAttempts, "error", err)
+
+		if attempt == opts.MaxAttempts {
+			break
+		}
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+		backoff *= 2
+	}
+
+	return "", fmt.Errorf("download of %s failed after %d attempts: %w", url, opts.MaxAttempts, lastErr)
+}
+
+// downloadAttempt performs a single download attempt, resuming from the
+// existing size of partPath via a Range request when possible, and returns
+// the sha256 digest of the full file once complete.
+func downloadAttempt(ctx context.Context, url, partPath string, progressInterval time.Duration) (string, error) {
+	var startOffset int64
+	if info, err := os.Stat(partPath); err == nil {
+		startOffset = info.Size()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to build request for %s: %w", url, err)
+	}
+	if startOffset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", startOffset))
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	resuming := startOffset > 0 && resp.StatusCode == http.StatusPartialContent
+	flags := os.O_CREATE | os.O_WRONLY
+	if resuming {
+		flags |= os.O_APPEND
+	} else {
+		flags |= os.O_TRUNC
+		startOffset = 0
+		if resp.StatusCode != http.StatusOK {
+			return "", fmt.Errorf("unexpected status code %d while fetching %s", resp.StatusCode, url)
+		}
+	}
+
+	out, err := os.OpenFile(partPath, flags, 0600)
+	if err != nil {
+		return "", fmt.Errorf("failed to open %s: %w", partPath, err)
+	}
+	defer out.Close()
+
+	hasher := sha256.New()
+	if resuming {
+		if err := hashExistingPrefix(partPath, startOffset, hasher); err != nil {
+			return "", err
+		}
+	}
+
+	total := resp.ContentLength
+	if total > 0 && resuming {
+		total += startOffset
+	}
+
+	body := &progressReader{r: resp.Body, url: url, total: total, read: startOffset, interval: progressInterval}
+	if _, err := io.Copy(out, io.TeeReader(body, hasher)); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", partPath, err)
+	}
+
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// hashExistingPrefix feeds the first n bytes of path into hasher, used to
+// fold an already-downloaded prefix into the digest of a resumed transfer.
+func hashExistingPrefix(path string, n int64, hasher io.Writer) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to reopen %s to hash existing content: %w", path, err)
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(hasher, io.LimitReader(f, n)); err != nil {
+		return fmt.Errorf("failed to hash existing content of %s: %w", path, err)
+	}
+	return nil
+}
+
+// progressReader wraps an io.Reader, periodically logging bytes read versus
+// the expected total.
+type progressReader struct {
+	r        io.Reader
+	url      string
+	total    int64
+	read     int64
+	interval time.Duration
+	last     time.Time
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	p.read += int64(n)
+
+	if p.interval > 0 && time.Since(p.last) >= p.interval {
+		p.last = time.Now()
+		klog.V(2).InfoS("[DownloadResumable] progress", "url", p.url, "bytesRead", p.read, "totalBytes", p.total)
+	}
+	return n, err
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2023 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDownloadResumableFullDownload(t *testing.T) {
+	content := []byte("the quick brown fox jumps over the lazy dog")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(content)
+	}))
+	defer server.Close()
+
+	dst := filepath.Join(t.TempDir(), "out")
+	digest, err := DownloadResumable(context.Background(), server.URL, dst, DefaultDownloadOptions())
+	if err != nil {
+		t.Fatalf("DownloadResumable returned error: %v", err)
+	}
+
+	wantDigest := sha256.Sum256(content)
+	if digest != hex.EncodeToString(wantDigest[:]) {
+		t.Errorf("digest = %q, want %q", digest, hex.EncodeToString(wantDigest[:]))
+	}
+
+	got, err := os.ReadFile(dst)
+	if err != nil {
+		t.Fatalf("failed to read downloaded file: %v", err)
+	}
+	if string(got) != string(content) {
+		t.Errorf("downloaded content = %q, want %q", got, content)
+	}
+
+	if _, err := os.Stat(dst + ".part"); !os.IsNotExist(err) {
+		t.Errorf("expected .part file to be cleaned up, stat err = %v", err)
+	}
+}
+
+func TestDownloadResumableResumesFromPartialFile(t *testing.T) {
+	content := []byte("the quick brown fox jumps over the lazy dog")
+	split := 10
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rangeHeader := r.Header.Get("Range")
+		if rangeHeader == "" {
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(content)
+			return
+		}
+
+		w.Header().Set("Content-Range", "bytes "+strconv.Itoa(split)+"-/*")
+		w.WriteHeader(http.StatusPartialContent)
+		_, _ = w.Write(content[split:])
+	}))
+	defer server.Close()
+
+	dst := filepath.Join(t.TempDir(), "out")
+	if err := os.WriteFile(dst+".part", content[:split], 0600); err != nil {
+		t.Fatalf("failed to seed partial file: %v", err)
+	}
+
+	digest, err := DownloadResumable(context.Background(), server.URL, dst, DefaultDownloadOptions())
+	if err != nil {
+		t.Fatalf("DownloadResumable returned error: %v", err)
+	}
+
+	wantDigest := sha256.Sum256(content)
+	if digest != hex.EncodeToString(wantDigest[:]) {
+		t.Errorf("digest = %q, want %q", digest, hex.EncodeToString(wantDigest[:]))
+	}
+
+	got, err := os.ReadFile(dst)
+	if err != nil {
+		t.Fatalf("failed to read downloaded file: %v", err)
+	}
+	if string(got) != string(content) {
+		t.Errorf("downloaded content = %q, want %q", got, content)
+	}
+}
+
+func TestDownloadResumableRetriesOnFailure(t *testing.T) {
+	content := []byte("retry me")
+	var attempts int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write(content)
+	}))
+	defer server.Close()
+
+	dst := filepath.Join(t.TempDir(), "out")
+	opts := DefaultDownloadOptions()
+	opts.MaxAttempts = 2
+	opts.PerAttemptTimeout = 5 * time.Second
+
+	if _, err := DownloadResumable(context.Background(), server.URL, dst, opts); err != nil {
+		t.Fatalf("DownloadResumable returned error: %v", err)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 2 {
+		t.Errorf("expected 2 attempts, got %d", got)
+	}
+}
+
+func TestDownloadResumableExhaustsAttempts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	dst := filepath.Join(t.TempDir(), "out")
+	opts := DefaultDownloadOptions()
+	opts.MaxAttempts = 2
+	opts.PerAttemptTimeout = 5 * time.Second
+
+	if _, err := DownloadResumable(context.Background(), server.URL, dst, opts); err == nil {
+		t.Fatalf("expected an error after exhausting attempts, got none")
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2023 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import "testing"
+
+func TestParseOCIReference(t *testing.T) {
+	tests := []struct {
+		name           string
+		reference      string
+		wantRegistry   string
+		wantRepository string
+		wantRef        string
+		wantErr        bool
+	}{
+		{
+			name:           "tag",
+			reference:      "registry.example.com/karmada/crds:v1.9.0",
+			wantRegistry:   "registry.example.com",
+			wantRepository: "karmada/crds",
+			wantRef:        "v1.9.0",
+		},
+		{
+			name:           "digest",
+			reference:      "registry.example.com/karmada/crds@sha256:abc123",
+			wantRegistry:   "registry.example.com",
+			wantRepository: "karmada/crds",
+			wantRef:        "sha256:abc123",
+		},
+		{
+			name:           "bare reference defaults to latest",
+			reference:      "registry.example.com/karmada/crds",
+			wantRegistry:   "registry.example.com",
+			wantRepository: "karmada/crds",
+			wantRef:        "latest",
+		},
+		{
+			name:           "registry with a port",
+			reference:      "registry.example.com:5000/karmada/crds:v1",
+			wantRegistry:   "registry.example.com:5000",
+			wantRepository: "karmada/crds",
+			wantRef:        "v1",
+		},
+		{
+			name:      "no slash at all",
+			reference: "crds:v1",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry, repository, ref, err := parseOCIReference(tt.reference)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if registry != tt.wantRegistry {
+				t.Errorf("registry = %q, want %q", registry, tt.wantRegistry)
+			}
+			if repository != tt.wantRepository {
+				t.Errorf("repository = %q, want %q", repository, tt.wantRepository)
+			}
+			if ref != tt.wantRef {
+				t.Errorf("ref = %q, want %q", ref, tt.wantRef)
+			}
+		})
+	}
+}
+
+func TestSha256HexFromDigest(t *testing.T) {
+	hex, err := sha256HexFromDigest("sha256:abc123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if hex != "abc123" {
+		t.Errorf("got %q, want %q", hex, "abc123")
+	}
+
+	if _, err := sha256HexFromDigest("sha512:abc123"); err == nil {
+		t.Errorf("expected an error for an unsupported digest algorithm")
+	}
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2023 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+)
+
+// DownloadFileWithSHA256 downloads url into dst while streaming the content
+// through a sha256 hash, returning its hex-encoded digest. The destination
+// file is left in place on error so callers can inspect or remove it.
+func DownloadFileWithSHA256(ctx context.Context, url, dst string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to build request for %s: %w", url, err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status code %d while fetching %s", resp.StatusCode, url)
+	}
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", dst, err)
+	}
+	defer out.Close()
+
+	hasher := sha256.New()
+	if _, err := io.Copy(out, io.TeeReader(resp.Body, hasher)); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", dst, err)
+	}
+
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// VerifyCosignSignature verifies the detached cosign signature at sigURL
+// against artifactPath using publicKeyPEM. It shells out to the cosign CLI,
+// which must be present on PATH; this avoids vendoring the full sigstore
+// client stack into the operator binary.
+func VerifyCosignSignature(ctx context.Context, artifactPath, sigURL, publicKeyPEM string) error {
+	keyFile, err := os.CreateTemp("", "cosign-pubkey-*.pem")
+	if err != nil {
+		return fmt.Errorf("failed to create temp public key file: %w", err)
+	}
+	defer os.Remove(keyFile.Name())
+
+	if _, err := keyFile.WriteString(publicKeyPEM); err != nil {
+		keyFile.Close()
+		return fmt.Errorf("failed to write temp public key file: %w", err)
+	}
+	keyFile.Close()
+
+	sigFile := artifactPath + ".sig"
+	if _, err := DownloadFileWithSHA256(ctx, sigURL, sigFile); err != nil {
+		return fmt.Errorf("failed to fetch signature from %s: %w", sigURL, err)
+	}
+	defer os.Remove(sigFile)
+
+	cmd := exec.CommandContext(ctx, "cosign", "verify-blob", //nolint:gosec
+		"--key", keyFile.Name(),
+		"--signature", sigFile,
+		artifactPath)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("cosign signature verification failed: %w, output: %s", err, out)
+	}
+	return nil
+}